@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"io/fs"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+// crossfadeTicks is how many Update ticks a background-track crossfade
+// takes to complete, about half a second at the default 60 TPS.
+const crossfadeTicks = 30
+
+// sfxManifest names the one-shot WAV effects HoloMeme ships with and where
+// to find them.
+var sfxManifest = map[string]string{
+	"switch": "resources/sfx/switch.wav",
+	"jump":   "resources/sfx/jump.wav",
+}
+
+// AudioManager owns the shared audio.Context, the current (and, mid-fade,
+// the outgoing) background track player, and a registry of named one-shot
+// SFX, so Game.Update doesn't have to juggle raw *audio.Player lifecycles
+// itself. It replaces the old ad-hoc audio-init block that used to live in
+// Game.Update.
+type AudioManager struct {
+	ctx *audio.Context
+	sfx map[string][]byte
+
+	bgPlayer      *audio.Player
+	fadingOut     *audio.Player
+	fadeTicksLeft int
+
+	// oneShots tracks SFX players handed out by Play so Update can close
+	// them once they finish; otherwise every Play call would leak a player.
+	oneShots []*audio.Player
+
+	volume float64
+	muted  bool
+}
+
+// NewAudioManager constructs a manager bound to ctx with full volume and no
+// loaded SFX; call Init to load the SFX registry before using Play.
+func NewAudioManager(ctx *audio.Context) *AudioManager {
+	return &AudioManager{
+		ctx:    ctx,
+		sfx:    make(map[string][]byte),
+		volume: 1,
+	}
+}
+
+// Init loads every SFX in sfxManifest from fsys.
+func (a *AudioManager) Init(fsys fs.FS) error {
+	for name, path := range sfxManifest {
+		b, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		a.sfx[name] = b
+	}
+	return nil
+}
+
+// Play fires a registered SFX by name as a one-shot WAV player. Unknown
+// names are silently ignored so callers don't need to guard every call.
+func (a *AudioManager) Play(name string) {
+	b, ok := a.sfx[name]
+	if !ok {
+		return
+	}
+	s, err := wav.Decode(a.ctx, bytes.NewReader(b))
+	if err != nil {
+		return
+	}
+	p, err := audio.NewPlayer(a.ctx, s)
+	if err != nil {
+		return
+	}
+	p.SetVolume(a.effectiveVolume())
+	p.Play()
+	a.oneShots = append(a.oneShots, p)
+}
+
+// Playing reports whether a background track is currently loaded.
+func (a *AudioManager) Playing() bool {
+	return a.bgPlayer != nil
+}
+
+// CrossfadeTo starts looping oggData as the new background track, ramping
+// it in while ramping any already-playing track out over crossfadeTicks
+// calls to Update.
+func (a *AudioManager) CrossfadeTo(oggData []byte, loopLength int64) error {
+	s, err := vorbis.Decode(a.ctx, bytes.NewReader(oggData))
+	if err != nil {
+		return err
+	}
+	loop := audio.NewInfiniteLoop(s, loopLength)
+	p, err := audio.NewPlayer(a.ctx, loop)
+	if err != nil {
+		return err
+	}
+	p.SetVolume(0)
+	p.Play()
+
+	if a.bgPlayer != nil {
+		if a.fadingOut != nil {
+			a.fadingOut.Close()
+		}
+		a.fadingOut = a.bgPlayer
+	}
+	a.bgPlayer = p
+	a.fadeTicksLeft = crossfadeTicks
+	return nil
+}
+
+// Update advances any in-progress crossfade by one tick and closes any
+// one-shot SFX players started by Play that have finished. Call it once per
+// Game.Update.
+func (a *AudioManager) Update() {
+	live := a.oneShots[:0]
+	for _, p := range a.oneShots {
+		if p.IsPlaying() {
+			live = append(live, p)
+		} else {
+			p.Close()
+		}
+	}
+	a.oneShots = live
+
+	if a.fadeTicksLeft <= 0 {
+		if a.fadingOut != nil {
+			a.fadingOut.Close()
+			a.fadingOut = nil
+		}
+		return
+	}
+
+	a.fadeTicksLeft--
+	t := 1 - float64(a.fadeTicksLeft)/float64(crossfadeTicks)
+	if a.bgPlayer != nil {
+		a.bgPlayer.SetVolume(t * a.effectiveVolume())
+	}
+	if a.fadingOut != nil {
+		a.fadingOut.SetVolume((1 - t) * a.effectiveVolume())
+	}
+}
+
+// SetVolume sets the master volume in [0,1], immediately applying it to the
+// active background track (outside of an in-progress crossfade, which owns
+// the volume ramp itself).
+func (a *AudioManager) SetVolume(v float64) {
+	switch {
+	case v < 0:
+		v = 0
+	case v > 1:
+		v = 1
+	}
+	a.volume = v
+	if a.bgPlayer != nil && a.fadeTicksLeft <= 0 {
+		a.bgPlayer.SetVolume(a.effectiveVolume())
+	}
+}
+
+// Volume returns the current master volume, ignoring mute.
+func (a *AudioManager) Volume() float64 {
+	return a.volume
+}
+
+// ToggleMute flips mute without touching the stored volume, so unmuting
+// restores the previous level.
+func (a *AudioManager) ToggleMute() {
+	a.muted = !a.muted
+	if a.bgPlayer != nil && a.fadeTicksLeft <= 0 {
+		a.bgPlayer.SetVolume(a.effectiveVolume())
+	}
+}
+
+func (a *AudioManager) effectiveVolume() float64 {
+	if a.muted {
+		return 0
+	}
+	return a.volume
+}
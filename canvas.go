@@ -0,0 +1,53 @@
+package main
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// trailScale shrinks the character sprite down to a daubable brush size
+// when it's stamped onto the canvas as a trail.
+const trailScale = 0.25
+
+// newCanvasImage allocates the persistent paint layer, sized to the logical
+// screen so it lines up with the background and sprite regardless of the
+// active monitor's device scale.
+func newCanvasImage() *ebiten.Image {
+	return ebiten.NewImage(screenWidth, screenHeight)
+}
+
+// hueForPointer derives a stable hue-rotation angle from a pointer ID so
+// each finger (or the mouse) paints in a consistent, distinct color.
+func hueForPointer(id int) float64 {
+	const steps = 12
+	n := id % steps
+	if n < 0 {
+		n += steps
+	}
+	return float64(n) * (2 * math.Pi / steps)
+}
+
+// stampTrail draws a hue-rotated copy of the current character sprite onto
+// the canvas at (x, y), leaving a colored trail behind the pointer.
+func (g *PlayScene) stampTrail(id, x, y int) {
+	pack := currentPack()
+	if pack == nil {
+		return
+	}
+	sx, sy := spriteX*frameWidth, spriteY*frameHeight
+	subImage := pack.Image.SubImage(image.Rect(sx-frameWidth, sy-frameHeight, sx, sy)).(*ebiten.Image)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(trailScale, trailScale)
+	op.GeoM.Translate(float64(x)-float64(frameWidth)*trailScale/2, float64(y)-float64(frameHeight)*trailScale/2)
+	op.ColorM.RotateHue(hueForPointer(id))
+	g.canvasImage.DrawImage(subImage, op)
+}
+
+// clearCanvas wipes the paint layer, triggered by a right-click or a
+// two-finger tap.
+func (g *PlayScene) clearCanvas() {
+	g.canvasImage.Clear()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// configFileName is the name of the small JSON config file HoloMeme keeps
+// next to its executable to remember user preferences across sessions.
+const configFileName = "holomeme_config.json"
+
+// Config holds the subset of runtime state that should survive a restart.
+type Config struct {
+	MonitorIndex int     `json:"monitorIndex"`
+	Fullscreen   bool    `json:"fullscreen"`
+	Volume       float64 `json:"volume"`
+}
+
+// configFilePath returns the path of the config file alongside the running
+// executable, falling back to the current directory if it can't be resolved.
+func configFilePath() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return configFileName
+	}
+	return filepath.Join(filepath.Dir(exe), configFileName)
+}
+
+// loadConfig reads the config file, returning defaults (full volume, zero
+// values otherwise) if it is missing or malformed rather than failing game
+// startup.
+func loadConfig() Config {
+	cfg := Config{Volume: 1}
+	b, err := os.ReadFile(configFilePath())
+	if err != nil {
+		return cfg
+	}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return Config{Volume: 1}
+	}
+	return cfg
+}
+
+// save writes the config back out next to the executable. Errors are
+// intentionally non-fatal: failing to persist a preference shouldn't crash
+// the game.
+func (c Config) save() error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFilePath(), b, 0644)
+}
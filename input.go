@@ -0,0 +1,70 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// PointerPhase describes where in its lifecycle a PointerEvent is.
+type PointerPhase int
+
+const (
+	PointerBegan PointerPhase = iota
+	PointerMoved
+	PointerEnded
+)
+
+// mousePointerID is the synthetic pointer ID used for the mouse so it can
+// flow through the same PointerEvent stream as touches.
+const mousePointerID = -1
+
+// PointerEvent is a unified representation of a mouse or touch input point.
+// It lets Update treat "something is pressed/dragging/released at X,Y"
+// identically regardless of whether it came from a mouse or a finger.
+type PointerEvent struct {
+	ID    int
+	X, Y  int
+	Phase PointerPhase
+}
+
+// pollPointers merges the left mouse button and every active touch point
+// into a single slice of PointerEvents for the current tick.
+func pollPointers() []PointerEvent {
+	var events []PointerEvent
+
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		x, y := ebiten.CursorPosition()
+		events = append(events, PointerEvent{mousePointerID, x, y, PointerBegan})
+	case ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft):
+		x, y := ebiten.CursorPosition()
+		events = append(events, PointerEvent{mousePointerID, x, y, PointerMoved})
+	case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
+		x, y := ebiten.CursorPosition()
+		events = append(events, PointerEvent{mousePointerID, x, y, PointerEnded})
+	}
+
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		x, y := ebiten.TouchPosition(id)
+		events = append(events, PointerEvent{int(id), x, y, PointerBegan})
+	}
+	for _, id := range ebiten.AppendTouchIDs(nil) {
+		if inpututil.TouchPressDuration(id) <= 1 {
+			// already reported as PointerBegan above this tick
+			continue
+		}
+		x, y := ebiten.TouchPosition(id)
+		events = append(events, PointerEvent{int(id), x, y, PointerMoved})
+	}
+	for _, id := range inpututil.AppendJustReleasedTouchIDs(nil) {
+		events = append(events, PointerEvent{int(id), 0, 0, PointerEnded})
+	}
+
+	return events
+}
+
+// activeTouchCount reports how many fingers are currently on the screen,
+// used to detect a two-finger tap.
+func activeTouchCount() int {
+	return len(ebiten.AppendTouchIDs(nil))
+}
@@ -3,9 +3,9 @@ package main
 import (
 	"bytes"
 	"embed"
+	"flag"
 	"fmt"
 	"image"
-	"image/color"
 	_ "image/png"
 	"log"
 
@@ -14,16 +14,30 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/audio"
-	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
 )
 
-// Game implements ebiten.Game interface.
+// Game is the top-level ebiten.Game. It owns window/monitor/device-scale
+// concerns, which apply no matter what's on screen, and delegates the
+// actual screen content to whichever Scene is active.
 type Game struct {
-	count  int
-	player *audio.Player
+	manager *SceneManager
+}
+
+// NewGame wires up the scene graph: Title is shown first, Play holds the
+// persistent painting/character state, Pause is reached from Play, and
+// Settings can be reached from Title, Play, or Pause.
+func NewGame() *Game {
+	manager := &SceneManager{}
+	play := newPlayScene(manager)
+	settings := newSettingsScene(manager)
+	pause := newPauseScene(manager, play, settings)
+	title := newTitleScene(manager, play, settings)
+	play.pause = pause
+	play.settings = settings
+
+	manager.stack = []Scene{title}
+	return &Game{manager: manager}
 }
 
 const (
@@ -34,9 +48,19 @@ const (
 
 	instructionsText = `←: Kalm
 →: Gotta Go Fast
-Space: Switch character`
+Space: Switch character
+Hold click/touch: Paint
+Right-click/2-finger tap: Clear paint
++/-: Volume, M: Mute
+F6: CRT filter
+Escape: Pause, S: Settings`
 
 	sampleRate = 44100
+
+	// bgmLoopLength is how many bytes of decoded background music play
+	// before looping back to the start: 8 seconds of 16-bit stereo audio
+	// (4 bytes per sample frame) at sampleRate.
+	bgmLoopLength = 8 * 4 * sampleRate
 )
 
 var (
@@ -55,9 +79,6 @@ var (
 	frameHeight     = 720
 	tickPerFrame    = 6
 	animScale       = 0.8
-	currentChar     *ebiten.Image
-	ameImage        *ebiten.Image
-	kfcImage        *ebiten.Image
 	backgroundImage *ebiten.Image
 
 	audioContext    = audio.NewContext(sampleRate)
@@ -66,6 +87,30 @@ var (
 	speedUpAnimKey  = ebiten.KeyRight
 	slowDownAnimKey = ebiten.KeyLeft
 	changeCharaKey  = ebiten.KeySpace
+	pauseKey        = ebiten.KeyEscape
+
+	fullscreenKey  = ebiten.KeyF11
+	borderlessKey  = ebiten.KeyF10
+	nextMonitorKey = ebiten.KeyF9
+
+	volumeUpKey   = ebiten.KeyEqual
+	volumeDownKey = ebiten.KeyMinus
+	muteKey       = ebiten.KeyM
+
+	cfg = loadConfig()
+
+	audioManager = NewAudioManager(audioContext)
+
+	monitors       []*ebiten.Monitor
+	monitorIndex   int
+	currentMonitor *ebiten.Monitor
+	fullscreen     bool
+	borderless     bool
+
+	// deviceScale is the active monitor's DeviceScaleFactor, refreshed every
+	// LayoutF call so LayoutF can report the screen size in physical pixels;
+	// Game.Draw then scales the rendered scene up to fill that size.
+	deviceScale = 1.0
 )
 
 func init() {
@@ -102,75 +147,97 @@ func init() {
 	}
 	backgroundImage = ebiten.NewImageFromImage(image.Image(backGroundB))
 
-	b, err = f.ReadFile("resources/images/ameSprite.png")
-	if err != nil {
-		log.Fatal(err)
-	}
-	ameImageB, _, err := image.Decode(bytes.NewReader(b))
-	if err != nil {
+	loadEmbeddedCharacterPacks(f)
+	applyCurrentPack()
+
+	if err := audioManager.Init(f); err != nil {
 		log.Fatal(err)
 	}
-	ameImage = ebiten.NewImageFromImage(image.Image(ameImageB))
+	audioManager.SetVolume(cfg.Volume)
 
-	b, err = f.ReadFile("resources/images/kfcSprite.png")
-	if err != nil {
-		log.Fatal(err)
+	monitors = ebiten.AppendMonitors(nil)
+	monitorIndex = cfg.MonitorIndex
+	if monitorIndex < 0 || monitorIndex >= len(monitors) {
+		monitorIndex = 0
 	}
-	kfcImageB, _, err := image.Decode(bytes.NewReader(b))
-	if err != nil {
-		log.Fatal(err)
+	if len(monitors) > 0 {
+		currentMonitor = monitors[monitorIndex]
 	}
-	kfcImage = ebiten.NewImageFromImage(image.Image(kfcImageB))
-	currentChar = ameImage
+	fullscreen = cfg.Fullscreen
 }
 
-// Update proceeds the game state.
-// Update is called every tick (1/60 [s] by default).
+// Update advances window-level state that applies regardless of the active
+// scene, then delegates to the SceneManager.
 func (g *Game) Update() error {
-	if debugMode {
-		cursorPosition = getCursorPosition()
-	}
-
 	switch {
-	case inpututil.IsKeyJustPressed(speedUpAnimKey):
-		if tickPerFrame > 1 {
-			tickPerFrame--
-		}
-	case inpututil.IsKeyJustPressed(slowDownAnimKey):
-		if tickPerFrame < 8 {
-			tickPerFrame++
-		}
-	case inpututil.IsKeyJustPressed(changeCharaKey):
-		switch currentChar {
-		case ameImage:
-			currentChar = kfcImage
-		case kfcImage:
-			currentChar = ameImage
-		}
+	case inpututil.IsKeyJustPressed(fullscreenKey),
+		ebiten.IsKeyPressed(ebiten.KeyAltLeft) && inpututil.IsKeyJustPressed(ebiten.KeyEnter),
+		ebiten.IsKeyPressed(ebiten.KeyAltRight) && inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		toggleFullscreen()
+	case inpututil.IsKeyJustPressed(borderlessKey):
+		toggleBorderless()
+	case inpututil.IsKeyJustPressed(nextMonitorKey):
+		cycleMonitor()
 	//30 frames
 	case inpututil.KeyPressDuration(debugKey) == 30:
 		debugMode = !debugMode
 	}
 
-	if g.player != nil {
-		g.count++
-		return nil
-	}
-	oggS, err := vorbis.Decode(audioContext, bytes.NewReader(backgroundMusic))
-	if err != nil {
-		return err
+	if debugMode {
+		cursorPosition = getCursorPosition()
 	}
 
-	s := audio.NewInfiniteLoop(oggS, 8*4*sampleRate)
+	return g.manager.Update()
+}
+
+// toggleFullscreen flips exclusive fullscreen mode and persists the choice.
+func toggleFullscreen() {
+	fullscreen = !fullscreen
+	ebiten.SetFullscreen(fullscreen)
+	cfg.Fullscreen = fullscreen
+	_ = cfg.save()
+}
 
-	g.player, err = audio.NewPlayer(audioContext, s)
-	if err != nil {
-		return err
+// toggleBorderless switches between a normal decorated window and a
+// borderless window sized to fill the current monitor.
+func toggleBorderless() {
+	borderless = !borderless
+	ebiten.SetWindowDecorated(!borderless)
+	if borderless && currentMonitor != nil {
+		w, h := currentMonitor.Size()
+		ebiten.SetWindowSize(w, h)
+		ebiten.SetWindowPosition(currentMonitor.Bounds().Min.X, currentMonitor.Bounds().Min.Y)
+	} else {
+		ebiten.SetWindowSize(screenWidth, screenHeight)
 	}
+}
+
+// cycleMonitorDir steps dir monitors forward (positive) or backward
+// (negative) through the connected list, wrapping around, moves the window
+// there, and remembers the choice for next launch.
+func cycleMonitorDir(dir int) {
+	if len(monitors) == 0 {
+		return
+	}
+	monitorIndex = (monitorIndex + dir%len(monitors) + len(monitors)) % len(monitors)
+	currentMonitor = monitors[monitorIndex]
+	b := currentMonitor.Bounds()
+	ebiten.SetWindowPosition(b.Min.X, b.Min.Y)
+	cfg.MonitorIndex = monitorIndex
+	_ = cfg.save()
+}
+
+// cycleMonitor advances to the next connected monitor.
+func cycleMonitor() {
+	cycleMonitorDir(1)
+}
 
-	g.player.Play()
-	g.count++
-	return nil
+// setVolume clamps and applies a new master volume, persisting it to the
+// config file.
+func setVolume(v float64) {
+	audioManager.SetVolume(v)
+	cfg.Volume = audioManager.Volume()
+	_ = cfg.save()
 }
 
 func getCursorPosition() string {
@@ -179,58 +246,61 @@ func getCursorPosition() string {
 
 }
 
-// Draw draws the game screen.
-// Draw is called every frame (typically 1/60[s] for 60Hz display).
+// Draw renders every scene into a fixed logical-size buffer, scales that
+// buffer up to the device-pixel screen size LayoutF reported, then runs the
+// result through the active PostEffect chain (e.g. the CRT shader) before
+// it's presented. Scaling happens once here rather than in each scene, so
+// the background, paint canvas, sprite, and HUD all stay aligned on HiDPI
+// displays instead of only whichever layer remembers to compensate.
 func (g *Game) Draw(screen *ebiten.Image) {
-	screen.DrawImage(backgroundImage, nil)
+	ensureBuffer(&logicalBuffer, screenWidth, screenHeight)
+	g.manager.Draw(logicalBuffer)
 
-	if g.count%int(tickPerFrame) == 0 {
-		g.count = 24
-		spriteX++
-	}
-	if spriteX > 6 {
-		spriteX = 1
-		spriteY++
-	}
-	if spriteY > 4 {
-		spriteY = 1
-		animScale = 0.8
-	}
-	sx, sy := spriteX*frameWidth, spriteY*frameHeight
-	subImage := currentChar.SubImage(image.Rect(sx-frameWidth, sy-frameHeight, sx, sy))
+	b := screen.Bounds()
+	ensureBuffer(&sceneBuffer, b.Dx(), b.Dy())
+	sceneBuffer.Clear()
 	op := &ebiten.DrawImageOptions{}
-	x, y := ebiten.CursorPosition()
-	op.GeoM.Scale(animScale, animScale)
-	op.GeoM.Translate(float64(x-frameWidth*int(animScale)), float64(y-frameHeight*int(animScale)))
-	screen.DrawImage(subImage.(*ebiten.Image), op)
-	animScale -= 0.0033
-
-	text.Draw(screen, instructionsText, normalFont, 8, 75, color.White)
+	op.GeoM.Scale(float64(b.Dx())/screenWidth, float64(b.Dy())/screenHeight)
+	sceneBuffer.DrawImage(logicalBuffer, op)
 
-	if debugMode {
-		ebitenutil.DebugPrintAt(screen, cursorPosition, 0, 0)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("FPS：%.2f", ebiten.CurrentFPS()), 0, 15)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("TPS：%.2f", ebiten.CurrentTPS()), 0, 30)
-	}
+	renderWithPostEffects(screen, sceneBuffer)
 }
 
-// Layout accepts a native outside size in device-independent pixels and returns the game's logical
-// screen size. On desktops, the outside is a window or a monitor (fullscreen mode)
-//
-// Even though the outside size and the screen size differ, the rendering scale is automatically
-// adjusted to fit with the outside.
-//
-// You can return a fixed screen size if you don't care, or you can also return a calculated screen
-// size adjusted with the given outside size.
+// Layout satisfies the ebiten.Game interface required by ebiten.RunGame.
+// Go requires *Game to have this method regardless, but ebiten calls LayoutF
+// instead of Layout at runtime whenever a Game implements LayoutFer, so this
+// body never actually runs.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return screenWidth, screenHeight
 }
 
+// LayoutF accepts a native outside size in device-independent pixels and
+// returns the screen size in physical pixels, implementing
+// ebiten's LayoutFer interface. Ebiten sizes the *ebiten.Image passed to
+// Draw to match the returned value, so scaling it here by the active
+// monitor's DeviceScaleFactor makes Draw receive a screen sized to physical
+// pixels instead of a blurrier upscaled logical one.
+func (g *Game) LayoutF(outsideWidth, outsideHeight float64) (float64, float64) {
+	deviceScale = 1
+	if currentMonitor != nil {
+		deviceScale = currentMonitor.DeviceScaleFactor()
+	}
+	return outsideWidth * deviceScale, outsideHeight * deviceScale
+}
+
 func main() {
+	flag.Parse()
+	crtEnabled = *crtFlag
+	rebuildPostEffects()
+
 	ebiten.SetCursorMode(ebiten.CursorModeHidden)
 	ebiten.SetWindowSize(screenWidth, screenHeight)
 	ebiten.SetWindowTitle(`word.exe`)
-	if err := ebiten.RunGame(&Game{}); err != nil {
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	if fullscreen {
+		ebiten.SetFullscreen(true)
+	}
+	if err := ebiten.RunGame(NewGame()); err != nil {
 		log.Fatal(err)
 	}
 }
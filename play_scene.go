@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// PlayScene is the main gameplay loop: the cursor-follow animation plus the
+// painting canvas. It's the persistent scene Title switches into and that
+// Pause/Settings are reached from.
+type PlayScene struct {
+	manager  *SceneManager
+	pause    *PauseScene
+	settings *SettingsScene
+
+	count int
+
+	// canvasImage is the persistent paint layer stamped by the painting
+	// subsystem; it's drawn between the background and the sprite.
+	canvasImage *ebiten.Image
+}
+
+// newPlayScene constructs a PlayScene with its persistent layers allocated.
+func newPlayScene(manager *SceneManager) *PlayScene {
+	return &PlayScene{
+		manager:     manager,
+		canvasImage: newCanvasImage(),
+	}
+}
+
+// Update proceeds the game state.
+// Update is called every tick (1/60 [s] by default).
+func (g *PlayScene) Update() error {
+	if inpututil.IsKeyJustPressed(pauseKey) {
+		g.manager.Push(g.pause)
+		return nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.manager.Push(g.settings)
+		return nil
+	}
+
+	justPressedTouches := inpututil.AppendJustPressedTouchIDs(nil)
+	cleared := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) ||
+		(len(justPressedTouches) > 0 && activeTouchCount() >= 2)
+	if cleared {
+		// The two-finger tap that triggers a clear also shows up as
+		// PointerBegan for both touches in this same tick's pollPointers,
+		// so stamping here would immediately re-paint onto what was just
+		// cleared.
+		g.clearCanvas()
+	} else {
+		for _, ev := range pollPointers() {
+			if ev.Phase == PointerBegan || ev.Phase == PointerMoved {
+				g.stampTrail(ev.ID, ev.X, ev.Y)
+			}
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(speedUpAnimKey):
+		if tickPerFrame > 1 {
+			tickPerFrame--
+		}
+		audioManager.Play("jump")
+	case inpututil.IsKeyJustPressed(slowDownAnimKey):
+		if tickPerFrame < 8 {
+			tickPerFrame++
+		}
+		audioManager.Play("jump")
+	case inpututil.IsKeyJustPressed(changeCharaKey):
+		switchToPack(1)
+		audioManager.Play("switch")
+	case inpututil.IsKeyJustPressed(volumeUpKey):
+		setVolume(audioManager.Volume() + 0.1)
+	case inpututil.IsKeyJustPressed(volumeDownKey):
+		setVolume(audioManager.Volume() - 0.1)
+	case inpututil.IsKeyJustPressed(muteKey):
+		audioManager.ToggleMute()
+	case inpututil.IsKeyJustPressed(crtToggleKey):
+		toggleCRT()
+	}
+
+	// Animation state (spriteX/spriteY/animScale) only ever advances here,
+	// in Update, never in Draw — otherwise Pause's re-draws of this scene
+	// each frame would keep the animation running while "paused".
+	if pack := currentPack(); pack != nil {
+		if g.count%int(tickPerFrame) == 0 {
+			g.count = 24
+			spriteX++
+		}
+		if spriteX > pack.Columns {
+			spriteX = 1
+			spriteY++
+		}
+		if spriteY > pack.Rows {
+			spriteY = 1
+			animScale = 0.8
+		}
+		animScale -= 0.0033
+	}
+
+	audioManager.Update()
+	if !audioManager.Playing() {
+		if err := audioManager.CrossfadeTo(backgroundMusic, bgmLoopLength); err != nil {
+			return err
+		}
+	}
+
+	g.count++
+	return nil
+}
+
+// Draw renders the background, paint canvas, character sprite, and HUD
+// text.
+func (g *PlayScene) Draw(screen *ebiten.Image) {
+	pack := currentPack()
+
+	bg := backgroundImage
+	if pack != nil && pack.Background != nil {
+		bg = pack.Background
+	}
+	screen.DrawImage(bg, nil)
+	screen.DrawImage(g.canvasImage, nil)
+
+	if pack == nil {
+		return
+	}
+
+	sx, sy := spriteX*frameWidth, spriteY*frameHeight
+	subImage := pack.Image.SubImage(image.Rect(sx-frameWidth, sy-frameHeight, sx, sy))
+	op := &ebiten.DrawImageOptions{}
+	x, y := ebiten.CursorPosition()
+	op.GeoM.Scale(animScale, animScale)
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(subImage.(*ebiten.Image), op)
+
+	text.Draw(screen, instructionsText, normalFont, 8, 75, color.White)
+
+	if debugMode {
+		ebitenutil.DebugPrintAt(screen, cursorPosition, 0, 0)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("FPS：%.2f", ebiten.CurrentFPS()), 0, 15)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("TPS：%.2f", ebiten.CurrentTPS()), 0, 30)
+	}
+}
+
+// Layout implements Scene.
+func (g *PlayScene) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
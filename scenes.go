@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// Scene is one screen of the game: title, play, pause, or settings. Giving
+// each its own update/draw/layout keeps Game a thin dispatcher instead of
+// conflating every mode into one loop.
+type Scene interface {
+	Update() error
+	Draw(screen *ebiten.Image)
+	Layout(outsideWidth, outsideHeight int) (int, int)
+}
+
+// SceneManager holds a stack of scenes. Pushing (e.g. Pause over Play)
+// keeps the scene beneath alive so popping returns to it with its state
+// intact; SwitchTo replaces the whole stack for one-way transitions like
+// Title -> Play.
+type SceneManager struct {
+	stack []Scene
+}
+
+// Current returns the active scene, or nil if the stack is empty.
+func (m *SceneManager) Current() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// Push adds a new scene on top of the stack.
+func (m *SceneManager) Push(s Scene) {
+	m.stack = append(m.stack, s)
+}
+
+// Pop removes the top scene, returning to whatever is beneath it. Popping
+// the last scene is a no-op; there's always something to show.
+func (m *SceneManager) Pop() {
+	if len(m.stack) > 1 {
+		m.stack = m.stack[:len(m.stack)-1]
+	}
+}
+
+// SwitchTo replaces the entire stack with a single scene.
+func (m *SceneManager) SwitchTo(s Scene) {
+	m.stack = []Scene{s}
+}
+
+func (m *SceneManager) Update() error {
+	if s := m.Current(); s != nil {
+		return s.Update()
+	}
+	return nil
+}
+
+func (m *SceneManager) Draw(screen *ebiten.Image) {
+	if s := m.Current(); s != nil {
+		s.Draw(screen)
+	}
+}
+
+// TitleScene shows the instructions and waits for the player to start.
+type TitleScene struct {
+	manager  *SceneManager
+	play     *PlayScene
+	settings *SettingsScene
+}
+
+func newTitleScene(manager *SceneManager, play *PlayScene, settings *SettingsScene) *TitleScene {
+	return &TitleScene{manager: manager, play: play, settings: settings}
+}
+
+func (s *TitleScene) Update() error {
+	switch {
+	case inpututil.IsKeyJustPressed(changeCharaKey):
+		s.manager.SwitchTo(s.play)
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		s.manager.Push(s.settings)
+	}
+	return nil
+}
+
+func (s *TitleScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	text.Draw(screen, instructionsText, normalFont, 8, 75, color.White)
+	text.Draw(screen, "Press Space to start    S: Settings", normalFont, 8, 220, color.White)
+}
+
+func (s *TitleScene) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// PauseScene dims and freezes Play (no gameplay Update, but the background
+// track keeps playing since it's not stopped, only left unticked) until the
+// player resumes or opens Settings.
+type PauseScene struct {
+	manager  *SceneManager
+	play     *PlayScene
+	settings *SettingsScene
+}
+
+func newPauseScene(manager *SceneManager, play *PlayScene, settings *SettingsScene) *PauseScene {
+	return &PauseScene{manager: manager, play: play, settings: settings}
+}
+
+func (s *PauseScene) Update() error {
+	switch {
+	case inpututil.IsKeyJustPressed(pauseKey):
+		s.manager.Pop()
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		s.manager.Push(s.settings)
+	}
+	return nil
+}
+
+var pauseOverlay *ebiten.Image
+
+func (s *PauseScene) Draw(screen *ebiten.Image) {
+	s.play.Draw(screen)
+
+	b := screen.Bounds()
+	ensureBuffer(&pauseOverlay, b.Dx(), b.Dy())
+	pauseOverlay.Fill(color.RGBA{0, 0, 0, 160})
+	screen.DrawImage(pauseOverlay, nil)
+
+	text.Draw(screen, "Paused", normalFont, 8, 400, color.White)
+	text.Draw(screen, "Escape: Resume    S: Settings", normalFont, 8, 440, color.White)
+}
+
+func (s *PauseScene) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// settingsItem is one selectable row in SettingsScene.
+type settingsItem int
+
+const (
+	settingsVolume settingsItem = iota
+	settingsCharacterPack
+	settingsCRT
+	settingsMonitor
+	settingsItemCount
+)
+
+// SettingsScene exposes volume, character pack, CRT, and monitor selection
+// as a keyboard-navigable menu, on top of whatever hotkeys already control
+// them.
+type SettingsScene struct {
+	manager  *SceneManager
+	selected settingsItem
+}
+
+func newSettingsScene(manager *SceneManager) *SettingsScene {
+	return &SettingsScene{manager: manager}
+}
+
+func (s *SettingsScene) Update() error {
+	switch {
+	case inpututil.IsKeyJustPressed(pauseKey):
+		s.manager.Pop()
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		s.selected = (s.selected - 1 + settingsItemCount) % settingsItemCount
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		s.selected = (s.selected + 1) % settingsItemCount
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		s.activate(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		s.activate(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		s.activate(1)
+	}
+	return nil
+}
+
+// activate applies whatever action the selected row represents, in the
+// given direction (-1 for Left, +1 for Right/Enter). Volume steps down or
+// up; character pack and monitor step backward or forward through their
+// list; CRT only toggles, so it ignores the direction.
+func (s *SettingsScene) activate(dir int) {
+	switch s.selected {
+	case settingsVolume:
+		setVolume(audioManager.Volume() + float64(dir)*0.1)
+	case settingsCharacterPack:
+		switchToPack(dir)
+	case settingsCRT:
+		toggleCRT()
+	case settingsMonitor:
+		cycleMonitorDir(dir)
+	}
+}
+
+func (s *SettingsScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{16, 16, 24, 255})
+
+	rows := []string{
+		fmt.Sprintf("Volume: %d%%", int(audioManager.Volume()*100)),
+		fmt.Sprintf("Character Pack: %s", settingsPackName()),
+		fmt.Sprintf("CRT Filter: %s", settingsOnOff(crtEnabled)),
+		fmt.Sprintf("Monitor: %d/%d", monitorIndex+1, len(monitors)),
+	}
+	for i, row := range rows {
+		marker := "  "
+		if settingsItem(i) == s.selected {
+			marker = "> "
+		}
+		text.Draw(screen, marker+row, normalFont, 40, 100+i*40, color.White)
+	}
+	text.Draw(screen, "Up/Down select, Enter change, Escape back", normalFont, 40, 100+len(rows)*40+30, color.White)
+}
+
+func settingsPackName() string {
+	if pack := currentPack(); pack != nil {
+		return pack.Name
+	}
+	return "-"
+}
+
+func settingsOnOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+func (s *SettingsScene) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"io/fs"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var crtFlag = flag.Bool("crt", false, "enable the CRT post-processing effect on startup")
+
+// PostEffect is one stage of a post-processing pipeline: it reads src and
+// writes the processed result into dst. Chaining several lets the pipeline
+// grow (bloom, grayscale, hue-shift, ...) without Draw knowing about any of
+// their internals.
+type PostEffect interface {
+	Apply(dst, src *ebiten.Image, time float64)
+}
+
+// CRTEffect renders scanlines, barrel distortion, chromatic aberration, and
+// a vignette via the Kage shader in resources/shaders/crt.kage.
+type CRTEffect struct {
+	shader *ebiten.Shader
+
+	ScanlineIntensity float64
+	Curvature         float64
+}
+
+// NewCRTEffect compiles the embedded CRT shader.
+func NewCRTEffect(fsys fs.FS) (*CRTEffect, error) {
+	src, err := fs.ReadFile(fsys, "resources/shaders/crt.kage")
+	if err != nil {
+		return nil, err
+	}
+	shader, err := ebiten.NewShader(src)
+	if err != nil {
+		return nil, err
+	}
+	return &CRTEffect{
+		shader:            shader,
+		ScanlineIntensity: 0.25,
+		Curvature:         0.08,
+	}, nil
+}
+
+// Apply implements PostEffect.
+func (e *CRTEffect) Apply(dst, src *ebiten.Image, time float64) {
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = map[string]interface{}{
+		"ScanlineIntensity": e.ScanlineIntensity,
+		"Curvature":         e.Curvature,
+		"Time":              time,
+	}
+	b := dst.Bounds()
+	dst.DrawRectShader(b.Dx(), b.Dy(), e.shader, op)
+}
+
+var (
+	crtToggleKey = ebiten.KeyF6
+
+	crtEffect   *CRTEffect
+	crtEnabled  bool
+	postEffects []PostEffect
+
+	// logicalBuffer is where scenes actually draw, always at the fixed
+	// logical screenWidth x screenHeight; Game.Draw scales the whole thing
+	// up into sceneBuffer in one pass, so no Scene needs to know about
+	// deviceScale.
+	logicalBuffer *ebiten.Image
+
+	sceneBuffer    *ebiten.Image
+	postEffectBufA *ebiten.Image
+	postEffectBufB *ebiten.Image
+	postEffectTime float64
+)
+
+func init() {
+	effect, err := NewCRTEffect(f)
+	if err != nil {
+		log.Printf("crt shader unavailable: %v", err)
+		return
+	}
+	crtEffect = effect
+}
+
+// rebuildPostEffects recomputes the active effect chain from the current
+// toggle state. Keeping it a pure function of the toggles (rather than
+// appending/removing in place) keeps the chain's order predictable as more
+// effects are added later.
+func rebuildPostEffects() {
+	postEffects = postEffects[:0]
+	if crtEnabled && crtEffect != nil {
+		postEffects = append(postEffects, crtEffect)
+	}
+}
+
+// toggleCRT flips the runtime CRT toggle.
+func toggleCRT() {
+	crtEnabled = !crtEnabled
+	rebuildPostEffects()
+}
+
+// ensureBuffer (re)allocates img if it's nil or the wrong size.
+func ensureBuffer(img **ebiten.Image, w, h int) {
+	if *img != nil {
+		b := (*img).Bounds()
+		if b.Dx() == w && b.Dy() == h {
+			return
+		}
+	}
+	*img = ebiten.NewImage(w, h)
+}
+
+// renderWithPostEffects draws the already-rendered scene through the active
+// PostEffect chain and presents the result on screen. With no effects
+// active it's a plain copy.
+func renderWithPostEffects(screen, scene *ebiten.Image) {
+	if len(postEffects) == 0 {
+		screen.DrawImage(scene, nil)
+		return
+	}
+
+	b := screen.Bounds()
+	ensureBuffer(&postEffectBufA, b.Dx(), b.Dy())
+	ensureBuffer(&postEffectBufB, b.Dx(), b.Dy())
+
+	postEffectTime += 1.0 / 60
+	src, dst := scene, postEffectBufA
+	other := postEffectBufB
+	for i, effect := range postEffects {
+		effect.Apply(dst, src, postEffectTime)
+		if i == len(postEffects)-1 {
+			screen.DrawImage(dst, nil)
+			return
+		}
+		src, dst, other = dst, other, src
+	}
+}